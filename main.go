@@ -5,23 +5,42 @@ package main
 // You may also need to run `go mod tidy` to download bubbletea and its
 // dependencies.
 import (
-	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 )
 
+// fileChangedMsg is delivered when the on-disk data file is modified by
+// another process (another tui-do instance, or a manual edit).
+type fileChangedMsg struct{}
+
 const (
 	dirName  = ".tui-do"
 	fileName = ".tui-do.json"
 
-	footer = "\nPress q or ctrl+c to quit.\n"
+	footer = "\nPress q or ctrl+c to quit. u to undo, ctrl+r to redo.\n"
+
+	// chromeHeightBelowViewport is the number of lines reserved below the
+	// scrolling items viewport for the pinned new-item/new-list inputs and
+	// the footer. The chrome above the viewport is measured instead of
+	// hardcoded, since renderKeysBar can wrap onto more than one line.
+	chromeHeightBelowViewport = 7
+
+	// maxHistory caps the undo/redo stacks so long sessions don't grow
+	// memory unbounded.
+	maxHistory = 100
 )
 
 var (
@@ -29,11 +48,45 @@ var (
 	noStyle       = lipgloss.NewStyle()
 	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("202"))
 	buttonStyle   = lipgloss.NewStyle().Background(lipgloss.Color("15")).Foreground(lipgloss.Color("0"))
+	overdueStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dueTodayStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
 )
 
 type listItem struct {
-	Item      string `json:"item"`
-	Completed bool   `json:"completed"`
+	Item      string     `json:"item"`
+	Completed bool       `json:"completed"`
+	DueDate   *time.Time `json:"dueDate,omitempty"`
+	Priority  int        `json:"priority,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+}
+
+// dueDateLayout is the format used both to display an item's due date and
+// to parse it back out of the edit modal's text input.
+const dueDateLayout = "2006-01-02"
+
+// sortMode controls the order screenItems() presents the current list's
+// items in; it never touches the stored order in m.lists.
+type sortMode int
+
+const (
+	sortManual sortMode = iota
+	sortDueDate
+	sortPriority
+	sortCompletedLast
+	numSortModes
+)
+
+func (s sortMode) String() string {
+	switch s {
+	case sortDueDate:
+		return "due date"
+	case sortPriority:
+		return "priority"
+	case sortCompletedLast:
+		return "completed last"
+	default:
+		return "manual"
+	}
 }
 
 type jsonData struct {
@@ -49,6 +102,29 @@ type model struct {
 	textInput        textinput.Model
 	newListTextInput textinput.Model
 	listStartOffset  int
+
+	filtering     bool
+	filterInput   textinput.Model
+	filterMatches []filterMatch
+
+	width    int
+	height   int
+	viewport viewport.Model
+
+	store      Store
+	fileEvents chan Event
+
+	undoStack []jsonData
+	redoStack []jsonData
+
+	sortMode sortMode
+
+	editing       bool
+	editItemIdx   int
+	editFocusIdx  int
+	editDueInput  textinput.Model
+	editPriInput  textinput.Model
+	editTagsInput textinput.Model
 }
 
 type screenItemKind int
@@ -66,6 +142,13 @@ type screenItem struct {
 	itemIdx int // for list items
 }
 
+// filterMatch pairs a screenItem surfaced by the fuzzy filter with the rune
+// positions that matched, so View can highlight them.
+type filterMatch struct {
+	item    screenItem
+	indexes []int
+}
+
 func (m *model) screenItems() []screenItem {
 	items := make([]screenItem, 0)
 	for i := range m.keys {
@@ -73,9 +156,8 @@ func (m *model) screenItems() []screenItem {
 	}
 
 	if len(m.keys) > 0 {
-		lst := m.lists[m.keys[m.selectedList]]
-		for i := range lst {
-			items = append(items, screenItem{kind: kindItem, itemIdx: i})
+		for _, realIdx := range m.displayItemOrder() {
+			items = append(items, screenItem{kind: kindItem, itemIdx: realIdx})
 		}
 	}
 
@@ -86,8 +168,330 @@ func (m *model) screenItems() []screenItem {
 	return items
 }
 
-func initialModel() *model {
-	lists, keys := loadItems()
+// displayItemOrder returns, for the current list, a permutation of real
+// item indices reflecting m.sortMode. It never reorders m.lists itself -
+// only the order items are displayed and addressed by screen position in.
+func (m *model) displayItemOrder() []int {
+	if len(m.keys) == 0 {
+		return nil
+	}
+	items := m.lists[m.keys[m.selectedList]]
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	switch m.sortMode {
+	case sortDueDate:
+		sort.SliceStable(order, func(a, b int) bool {
+			da, db := items[order[a]].DueDate, items[order[b]].DueDate
+			if da == nil {
+				return false
+			}
+			if db == nil {
+				return true
+			}
+			return da.Before(*db)
+		})
+	case sortPriority:
+		sort.SliceStable(order, func(a, b int) bool {
+			return items[order[a]].Priority > items[order[b]].Priority
+		})
+	case sortCompletedLast:
+		sort.SliceStable(order, func(a, b int) bool {
+			return !items[order[a]].Completed && items[order[b]].Completed
+		})
+	}
+
+	return order
+}
+
+// filterableItems returns the keys and current list's items eligible for
+// fuzzy filtering, i.e. screenItems() without the trailing input rows.
+func (m *model) filterableItems() []screenItem {
+	items := make([]screenItem, 0)
+	for i := range m.keys {
+		items = append(items, screenItem{kind: kindKey, keyIdx: i})
+	}
+	if len(m.keys) > 0 {
+		for _, realIdx := range m.displayItemOrder() {
+			items = append(items, screenItem{kind: kindItem, itemIdx: realIdx})
+		}
+	}
+	return items
+}
+
+func (m *model) filterItemText(si screenItem) string {
+	switch si.kind {
+	case kindKey:
+		return m.keys[si.keyIdx]
+	case kindItem:
+		return m.lists[m.keys[m.selectedList]][si.itemIdx].Item
+	default:
+		return ""
+	}
+}
+
+// updateFilterMatches re-scores filterableItems() against the current
+// filter query and resets the cursor onto the best match.
+func (m *model) updateFilterMatches() {
+	base := m.filterableItems()
+	query := strings.TrimSpace(m.filterInput.Value())
+	if query == "" {
+		matches := make([]filterMatch, len(base))
+		for i, si := range base {
+			matches[i] = filterMatch{item: si}
+		}
+		m.filterMatches = matches
+		m.cursor = 0
+		return
+	}
+
+	source := make([]string, len(base))
+	for i, si := range base {
+		source[i] = m.filterItemText(si)
+	}
+
+	results := fuzzy.Find(query, source)
+	matches := make([]filterMatch, len(results))
+	for i, r := range results {
+		matches[i] = filterMatch{item: base[r.Index], indexes: r.MatchedIndexes}
+	}
+	m.filterMatches = matches
+	m.cursor = 0
+}
+
+// updateFilter handles key events while the fuzzy filter prompt is open. It
+// mutates m.lists/m.keys directly through the real indices carried on each
+// filterMatch, rather than the on-screen cursor arithmetic Update uses.
+func (m *model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.filtering = false
+		m.filterInput.SetValue("")
+		m.filterInput.Blur()
+		m.filterInput.PromptStyle = noStyle
+		m.filterInput.TextStyle = noStyle
+		return m, nil
+
+	case "up", "shift+tab":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case "down", "tab":
+		if m.cursor < len(m.filterMatches)-1 {
+			m.cursor++
+		}
+		return m, nil
+
+	case "enter":
+		if m.cursor < 0 || m.cursor >= len(m.filterMatches) {
+			return m, nil
+		}
+		si := m.filterMatches[m.cursor].item
+		if si.kind == kindKey {
+			m.selectedList = si.keyIdx
+			m.filtering = false
+			m.filterInput.SetValue("")
+			m.filterInput.Blur()
+			m.filterInput.PromptStyle = noStyle
+			m.filterInput.TextStyle = noStyle
+			return m, nil
+		}
+		items := m.lists[m.keys[m.selectedList]]
+		m.pushUndo()
+		items[si.itemIdx].Completed = !items[si.itemIdx].Completed
+		return m, nil
+
+	case " ":
+		if m.cursor < 0 || m.cursor >= len(m.filterMatches) {
+			return m, nil
+		}
+		si := m.filterMatches[m.cursor].item
+		if si.kind == kindItem {
+			items := m.lists[m.keys[m.selectedList]]
+			m.pushUndo()
+			items[si.itemIdx].Completed = !items[si.itemIdx].Completed
+		}
+		return m, nil
+
+	case "d":
+		if m.cursor < 0 || m.cursor >= len(m.filterMatches) {
+			return m, nil
+		}
+		si := m.filterMatches[m.cursor].item
+		m.pushUndo()
+		if si.kind == kindKey {
+			deleteKey := m.keys[si.keyIdx]
+			newKeys := make([]string, 0, len(m.keys)-1)
+			for i, key := range m.keys {
+				if i != si.keyIdx {
+					newKeys = append(newKeys, key)
+				}
+			}
+			delete(m.lists, deleteKey)
+			m.keys = newKeys
+			if m.selectedList >= len(m.keys) {
+				m.selectedList = len(m.keys) - 1
+			}
+			if m.selectedList < 0 {
+				m.selectedList = 0
+			}
+			m.listStartOffset = len(m.keys)
+		} else {
+			items := m.lists[m.keys[m.selectedList]]
+			newItems := make([]listItem, 0, len(items)-1)
+			for i, item := range items {
+				if i != si.itemIdx {
+					newItems = append(newItems, item)
+				}
+			}
+			m.lists[m.keys[m.selectedList]] = newItems
+		}
+		m.updateFilterMatches()
+		return m, nil
+
+	default:
+		var cmd tea.Cmd
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		m.updateFilterMatches()
+		return m, cmd
+	}
+}
+
+// openEdit populates the edit modal's inputs from the item at realIdx in
+// the current list and opens it.
+func (m *model) openEdit(realIdx int) {
+	item := m.lists[m.keys[m.selectedList]][realIdx]
+
+	m.editing = true
+	m.editItemIdx = realIdx
+	m.editFocusIdx = 0
+
+	due := ""
+	if item.DueDate != nil {
+		due = item.DueDate.Format(dueDateLayout)
+	}
+	pri := ""
+	if item.Priority != 0 {
+		pri = strconv.Itoa(item.Priority)
+	}
+
+	m.editDueInput.SetValue(due)
+	m.editPriInput.SetValue(pri)
+	m.editTagsInput.SetValue(strings.Join(item.Tags, ", "))
+
+	m.editDueInput.PromptStyle = focusedStyle
+	m.editDueInput.TextStyle = focusedStyle
+	m.editPriInput.Blur()
+	m.editTagsInput.Blur()
+}
+
+// editInputs returns the modal's fields in tab order.
+func (m *model) editInputs() []*textinput.Model {
+	return []*textinput.Model{&m.editDueInput, &m.editPriInput, &m.editTagsInput}
+}
+
+func (m *model) focusEditField(idx int) tea.Cmd {
+	inputs := m.editInputs()
+	m.editFocusIdx = ((idx % len(inputs)) + len(inputs)) % len(inputs)
+	var cmd tea.Cmd
+	for i, in := range inputs {
+		if i == m.editFocusIdx {
+			in.PromptStyle = focusedStyle
+			in.TextStyle = focusedStyle
+			cmd = in.Focus()
+		} else {
+			in.Blur()
+			in.PromptStyle = noStyle
+			in.TextStyle = noStyle
+		}
+	}
+	return cmd
+}
+
+func (m *model) closeEdit() {
+	m.editing = false
+	for _, in := range m.editInputs() {
+		in.Blur()
+		in.PromptStyle = noStyle
+		in.TextStyle = noStyle
+	}
+}
+
+// applyEdit parses the modal's fields and writes them onto the edited
+// item. Invalid due dates and priorities are silently ignored rather than
+// blocking the save, since this modal has no error display of its own.
+func (m *model) applyEdit() {
+	m.pushUndo()
+
+	item := &m.lists[m.keys[m.selectedList]][m.editItemIdx]
+
+	dueText := strings.TrimSpace(m.editDueInput.Value())
+	if dueText == "" {
+		item.DueDate = nil
+	} else if parsed, err := time.Parse(dueDateLayout, dueText); err == nil {
+		item.DueDate = &parsed
+	}
+
+	priText := strings.TrimSpace(m.editPriInput.Value())
+	if priText == "" {
+		item.Priority = 0
+	} else if parsed, err := strconv.Atoi(priText); err == nil {
+		item.Priority = parsed
+	}
+
+	tagsText := strings.TrimSpace(m.editTagsInput.Value())
+	if tagsText == "" {
+		item.Tags = nil
+	} else {
+		var tags []string
+		for _, tag := range strings.Split(tagsText, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		item.Tags = tags
+	}
+}
+
+// updateEdit handles key events while the item edit modal is open.
+func (m *model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.closeEdit()
+		return m, nil
+
+	case "tab", "down":
+		return m, m.focusEditField(m.editFocusIdx + 1)
+
+	case "shift+tab", "up":
+		return m, m.focusEditField(m.editFocusIdx - 1)
+
+	case "enter":
+		m.applyEdit()
+		m.closeEdit()
+		return m, nil
+
+	default:
+		var cmd tea.Cmd
+		in := m.editInputs()[m.editFocusIdx]
+		*in, cmd = in.Update(msg)
+		return m, cmd
+	}
+}
+
+func initialModel(store Store) *model {
+	data, err := store.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	lists, keys := data.Lists, data.Keys
+	if lists == nil {
+		lists = make(map[string][]listItem)
+	}
 	selectedList := 0
 
 	ti := textinput.New()
@@ -98,6 +502,26 @@ func initialModel() *model {
 	newListTi.CharLimit = 156
 	newListTi.Width = 20
 
+	filterTi := textinput.New()
+	filterTi.CharLimit = 156
+	filterTi.Width = 30
+	filterTi.Prompt = "/"
+
+	editDueTi := textinput.New()
+	editDueTi.CharLimit = 10
+	editDueTi.Width = 12
+	editDueTi.Placeholder = dueDateLayout
+
+	editPriTi := textinput.New()
+	editPriTi.CharLimit = 3
+	editPriTi.Width = 4
+	editPriTi.Placeholder = "0"
+
+	editTagsTi := textinput.New()
+	editTagsTi.CharLimit = 156
+	editTagsTi.Width = 30
+	editTagsTi.Placeholder = "tag1, tag2"
+
 	listStartOffset := len(keys)
 
 	cursor := 0
@@ -113,6 +537,12 @@ func initialModel() *model {
 		textInput:        ti,
 		listStartOffset:  listStartOffset,
 		newListTextInput: newListTi,
+		filterInput:      filterTi,
+		viewport:         viewport.New(0, 0),
+		store:            store,
+		editDueInput:     editDueTi,
+		editPriInput:     editPriTi,
+		editTagsInput:    editTagsTi,
 	}
 
 	// Set initial focus/styles based on whether we have any lists
@@ -130,7 +560,148 @@ func initialModel() *model {
 }
 
 func (m *model) Init() tea.Cmd {
-	return textinput.Blink
+	cmds := []tea.Cmd{textinput.Blink}
+	if cmd := m.startWatching(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// startWatching asks the configured Store to watch for external changes
+// so another tui-do instance (or a manual edit) is picked up live. It
+// returns nil if the store's Watch fails. A store that accepts the watch
+// but never sends events (such as sqliteStore) leaves the returned command
+// waiting, so the app simply never reloads.
+func (m *model) startWatching() tea.Cmd {
+	m.fileEvents = make(chan Event, 1)
+	if err := m.store.Watch(m.fileEvents); err != nil {
+		return nil
+	}
+	return m.waitForFileChange()
+}
+
+func (m *model) waitForFileChange() tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-m.fileEvents; !ok {
+			return nil
+		}
+		return fileChangedMsg{}
+	}
+}
+
+// mergeJSONData reconciles externally-loaded data into the running model.
+// The incoming keys and lists replace the model's wholesale (last writer
+// wins for the whole document), but the selected list is preserved by key
+// name rather than index so a reload never yanks the user to a different
+// list out from under them. A cursor on an item keeps its position in that
+// list, clamped to the list's new length.
+func (m *model) mergeJSONData(incoming jsonData) {
+	var selectedKey string
+	if len(m.keys) > 0 {
+		selectedKey = m.keys[m.selectedList]
+	}
+
+	itemCursor := m.getItemCursor()
+	numItemsBefore := 0
+	if len(m.keys) > 0 {
+		numItemsBefore = len(m.lists[m.keys[m.selectedList]])
+	}
+	onItem := itemCursor >= 0 && itemCursor < numItemsBefore
+	onKey := m.cursor < len(m.keys)
+
+	m.keys = incoming.Keys
+	m.lists = incoming.Lists
+	m.listStartOffset = len(m.keys)
+
+	m.selectedList = 0
+	for i, k := range m.keys {
+		if k == selectedKey {
+			m.selectedList = i
+			break
+		}
+	}
+
+	switch {
+	case onItem && len(m.keys) > 0:
+		items := m.lists[m.keys[m.selectedList]]
+		if itemCursor >= len(items) {
+			itemCursor = len(items) - 1
+		}
+		if itemCursor < 0 {
+			itemCursor = 0
+		}
+		m.setCursor(itemCursor)
+	case onKey:
+		m.cursor = m.selectedList
+	}
+
+	// An incoming merge can shrink or reorder the current list out from
+	// under an open modal's stale indices, so close the edit modal (its
+	// editItemIdx may now be out of range) and rebuild the filter's matches
+	// against the new data rather than risk a stale-index panic in
+	// editView/applyEdit/filterView.
+	if m.editing {
+		m.closeEdit()
+	}
+	if m.filtering {
+		m.updateFilterMatches()
+	}
+
+	m.syncViewport()
+}
+
+// snapshot deep-copies the current lists/keys so it can be pushed onto the
+// undo/redo stacks without aliasing the live model state.
+func (m *model) snapshot() jsonData {
+	lists := make(map[string][]listItem, len(m.lists))
+	for k, v := range m.lists {
+		items := make([]listItem, len(v))
+		copy(items, v)
+		lists[k] = items
+	}
+	keys := make([]string, len(m.keys))
+	copy(keys, m.keys)
+	return jsonData{Keys: keys, Lists: lists}
+}
+
+// pushUndo records the state just before a mutating action, so `u` can
+// restore it. Any pending redo history is discarded, matching how undo
+// works in editors: once you do something new, the old redo branch is
+// gone.
+func (m *model) pushUndo() {
+	m.undoStack = append(m.undoStack, m.snapshot())
+	if len(m.undoStack) > maxHistory {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxHistory:]
+	}
+	m.redoStack = nil
+}
+
+func (m *model) undo() {
+	if len(m.undoStack) == 0 {
+		return
+	}
+	m.redoStack = append(m.redoStack, m.snapshot())
+	if len(m.redoStack) > maxHistory {
+		m.redoStack = m.redoStack[len(m.redoStack)-maxHistory:]
+	}
+
+	prev := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+	m.mergeJSONData(prev)
+}
+
+func (m *model) redo() {
+	if len(m.redoStack) == 0 {
+		return
+	}
+	m.undoStack = append(m.undoStack, m.snapshot())
+	if len(m.undoStack) > maxHistory {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxHistory:]
+	}
+
+	next := m.redoStack[len(m.redoStack)-1]
+	m.redoStack = m.redoStack[:len(m.redoStack)-1]
+	m.mergeJSONData(next)
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -161,17 +732,67 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.Width = m.width
+		vpHeight := m.height - m.headerHeight() - chromeHeightBelowViewport
+		if vpHeight < 1 {
+			vpHeight = 1
+		}
+		m.viewport.Height = vpHeight
+		m.syncViewport()
+		return m, nil
+
+	case tea.MouseMsg:
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+
+	case fileChangedMsg:
+		if data, err := m.store.Load(); err == nil {
+			m.mergeJSONData(data)
+		}
+		return m, m.waitForFileChange()
+
 	case tea.KeyMsg:
+		if m.editing {
+			return m.updateEdit(msg)
+		}
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
 		switch msg.String() {
+		case "/":
+			m.filtering = true
+			m.filterInput.SetValue("")
+			m.filterInput.PromptStyle = focusedStyle
+			m.filterInput.TextStyle = focusedStyle
+			m.updateFilterMatches()
+			return m, tea.Batch(m.filterInput.Focus(), textinput.Blink)
+		case "pgup", "pgdown":
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
 		case "ctrl+c", "q":
 			if msg.String() == "q" && itemCursor == numItems && numKeys > 0 {
 				break
 			}
 			m.SaveItems()
 			return m, tea.Quit
+		case "u":
+			// Only undo when the cursor isn't on a text input, so "u" can
+			// still be typed into new-item/new-list names.
+			if m.cursor != newItemIdx && m.cursor != newListIdx {
+				m.undo()
+				return m, nil
+			}
+		case "ctrl+r":
+			m.redo()
+			return m, nil
 		case "d":
 			// Deleting a list key or an item depending on cursor position
 			if m.cursor < numKeys {
+				m.pushUndo()
 				// Delete the key at cursor (including if it's the selected list)
 				deleteKeyIdx := m.cursor
 				deleteKey := m.keys[deleteKeyIdx]
@@ -226,9 +847,11 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if itemCursor < 0 || itemCursor >= numItems {
 				break
 			}
+			m.pushUndo()
+			realIdx := m.displayItemOrder()[itemCursor]
 			newItems := make([]listItem, 0, len(items)-1)
 			for i, item := range items {
-				if i != itemCursor {
+				if i != realIdx {
 					newItems = append(newItems, item)
 				}
 			}
@@ -262,6 +885,7 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor = total - 1
 			}
 
+			m.syncViewport()
 			return m, cmd
 
 		case "enter":
@@ -272,8 +896,10 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// If on an item, toggle completion
 			if itemCursor >= 0 && itemCursor < numItems {
-				completed := items[itemCursor].Completed
-				m.lists[m.keys[m.selectedList]][itemCursor].Completed = !completed
+				m.pushUndo()
+				realIdx := m.displayItemOrder()[itemCursor]
+				completed := items[realIdx].Completed
+				m.lists[m.keys[m.selectedList]][realIdx].Completed = !completed
 				break
 			}
 			// Add a new item only when lists exist and cursor is at the new-item input
@@ -282,6 +908,7 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if text == "" {
 					break
 				}
+				m.pushUndo()
 				m.lists[m.keys[m.selectedList]] = append(items, listItem{
 					Item:      text,
 					Completed: false,
@@ -298,6 +925,7 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if text == "" {
 					break
 				}
+				m.pushUndo()
 				m.keys = append(m.keys, text)
 				m.lists[text] = make([]listItem, 0)
 				// Select the newly created list
@@ -318,8 +946,23 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case " ":
 			// Only toggle when cursor is on a valid item
 			if itemCursor >= 0 && itemCursor < numItems {
-				completed := items[itemCursor].Completed
-				m.lists[m.keys[m.selectedList]][itemCursor].Completed = !completed
+				m.pushUndo()
+				realIdx := m.displayItemOrder()[itemCursor]
+				completed := items[realIdx].Completed
+				m.lists[m.keys[m.selectedList]][realIdx].Completed = !completed
+			}
+		case "e":
+			// Open the edit modal for the item under the cursor
+			if itemCursor >= 0 && itemCursor < numItems {
+				realIdx := m.displayItemOrder()[itemCursor]
+				m.openEdit(realIdx)
+				return m, tea.Batch(m.editDueInput.Focus(), textinput.Blink)
+			}
+		case "s":
+			// Only cycle sort mode when the cursor isn't on a text input,
+			// so "s" can still be typed into new-item/new-list names.
+			if m.cursor != newItemIdx && m.cursor != newListIdx {
+				m.sortMode = (m.sortMode + 1) % numSortModes
 			}
 		}
 	}
@@ -329,76 +972,205 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmd, newListCmd)
 }
 
-func (m *model) View() string {
-	s := "Your Tui-Dos\n\n"
+// renderMatch highlights the runes of text at the given matched indexes
+// using focusedStyle, leaving the rest unstyled.
+func renderMatch(text string, indexes []int) string {
+	if len(indexes) == 0 {
+		return text
+	}
+	matched := make(map[int]bool, len(indexes))
+	for _, idx := range indexes {
+		matched[idx] = true
+	}
+	var b strings.Builder
+	for i, r := range []rune(text) {
+		if matched[i] {
+			b.WriteString(focusedStyle.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
 
-	items := m.screenItems()
+func (m *model) filterView() string {
+	s := "Your Tui-Dos\n\n"
+	s += "Filter: " + m.filterInput.View() + "\n\n"
 
-	s += fmt.Sprintf("items: %d  cursor: %d\n\n", len(items), m.cursor)
-	for i, si := range items {
-		switch si.kind {
+	if len(m.filterMatches) == 0 {
+		s += "(no matches)\n"
+	}
+	for i, fm := range m.filterMatches {
+		c := " "
+		style := noStyle
+		if m.cursor == i {
+			c = ">"
+			style = selectedStyle
+		}
+		switch fm.item.kind {
 		case kindKey:
-			key := m.keys[si.keyIdx]
-			if m.cursor == i {
-				s += selectedStyle.Render(key) + "\t"
-			} else if m.selectedList == si.keyIdx {
-				s += buttonStyle.Render(key) + "\t"
-			} else {
-				s += fmt.Sprintf("%s\t", key)
-			}
-			if i+1 < len(items) && items[i+1].kind != kindKey {
-				s += "\n\n"
-			}
+			s += style.Render(c+" ") + renderMatch(m.keys[fm.item.keyIdx], fm.indexes) + "\n"
 		case kindItem:
-			item := m.lists[m.keys[m.selectedList]][si.itemIdx]
-			style := noStyle
-			c := " "
-			if m.cursor == i {
-				c = ">"
-				style = selectedStyle
-			}
+			item := m.lists[m.keys[m.selectedList]][fm.item.itemIdx]
 			checked := " "
 			if item.Completed {
 				checked = "x"
 			}
-			s += style.Render(fmt.Sprintf("%s [%s] %s", c, checked, item.Item)) + "\n"
-		case kindNewItem:
-			s += "\n\n" + m.textInput.View()
-		case kindNewList:
-			s += "\n\nAdd a new list: " + m.newListTextInput.View()
+			s += style.Render(fmt.Sprintf("%s [%s] ", c, checked)) + renderMatch(item.Item, fm.indexes) + "\n"
 		}
 	}
 
-	s += footer
+	s += "\nesc to cancel, enter/space to act, d to delete.\n"
+	return s
+}
+
+// renderKeysBar renders the list-name bar, wrapped to the
+// terminal width so it never overflows onto the items region below it.
+func (m *model) renderKeysBar(items []screenItem) string {
+	width := m.width
+	if width <= 0 {
+		width = 80
+	}
+	barStyle := lipgloss.NewStyle().Width(width)
+
+	var b strings.Builder
+	for i, si := range items {
+		if si.kind != kindKey {
+			continue
+		}
+		key := m.keys[si.keyIdx]
+		switch {
+		case m.cursor == i:
+			b.WriteString(selectedStyle.Render(key))
+		case m.selectedList == si.keyIdx:
+			b.WriteString(buttonStyle.Render(key))
+		default:
+			b.WriteString(key)
+		}
+		b.WriteString("  ")
+	}
+
+	return barStyle.Render(b.String())
+}
+
+// renderItems renders just the kindItem rows of the current list, for
+// display inside the scrolling viewport.
+func (m *model) renderItems(items []screenItem) string {
+	now := time.Now()
+	today := now.Format(dueDateLayout)
+
+	var s string
+	for i, si := range items {
+		if si.kind != kindItem {
+			continue
+		}
+		item := m.lists[m.keys[m.selectedList]][si.itemIdx]
+		style := noStyle
+		c := " "
+		selected := m.cursor == i
+		if selected {
+			c = ">"
+		}
+		checked := " "
+		if item.Completed {
+			checked = "x"
+		}
+
+		line := fmt.Sprintf("%s [%s] %s", c, checked, item.Item)
+		if item.Priority > 0 {
+			line += fmt.Sprintf(" (p%d)", item.Priority)
+		}
+		if item.DueDate != nil {
+			line += " due:" + item.DueDate.Format(dueDateLayout)
+			switch {
+			case !item.Completed && item.DueDate.Format(dueDateLayout) < today:
+				style = overdueStyle
+			case !item.Completed && item.DueDate.Format(dueDateLayout) == today:
+				style = dueTodayStyle
+			}
+		}
+		if len(item.Tags) > 0 {
+			line += " #" + strings.Join(item.Tags, " #")
+		}
+		if selected {
+			style = selectedStyle
+		}
 
+		s += style.Render(line) + "\n"
+	}
 	return s
 }
 
-func (m *model) SaveItems() {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
+// editView renders the modal for editing an item's due date, priority,
+// and tags.
+func (m *model) editView() string {
+	item := m.lists[m.keys[m.selectedList]][m.editItemIdx]
+
+	s := "Edit: " + item.Item + "\n\n"
+	s += "Due date (YYYY-MM-DD): " + m.editDueInput.View() + "\n"
+	s += "Priority:              " + m.editPriInput.View() + "\n"
+	s += "Tags (comma-separated):" + m.editTagsInput.View() + "\n"
+	s += "\nesc to cancel, enter to save, tab to switch fields.\n"
+	return s
+}
+
+// headerHeight measures how many lines View renders above the scrolling
+// items viewport, so the viewport can be sized exactly instead of guessing
+// a fixed chrome height - renderKeysBar wraps rather than truncates, so a
+// hardcoded count would overflow once there are enough lists. This must be
+// built the same way as the header in View.
+func (m *model) headerHeight() int {
+	header := "Your Tui-Dos\n\n"
+	header += fmt.Sprintf("Sort: %s (press s to cycle)\n\n", m.sortMode)
+	header += m.renderKeysBar(m.screenItems()) + "\n\n"
+	return strings.Count(header, "\n")
+}
+
+func (m *model) View() string {
+	if m.editing {
+		return m.editView()
+	}
+	if m.filtering {
+		return m.filterView()
 	}
 
-	dataDir := filepath.Join(homeDir, dirName)
-	// Ensure the data directory exists
-	if err := os.MkdirAll(dataDir, 0o755); err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
+	items := m.screenItems()
+
+	s := "Your Tui-Dos\n\n"
+	s += fmt.Sprintf("Sort: %s (press s to cycle)\n\n", m.sortMode)
+	s += m.renderKeysBar(items) + "\n\n"
+
+	m.viewport.SetContent(m.renderItems(items))
+	s += m.viewport.View() + "\n"
+
+	for i, si := range items {
+		switch si.kind {
+		case kindNewItem:
+			prefix := "  "
+			if m.cursor == i {
+				prefix = "> "
+			}
+			s += "\n" + prefix + m.textInput.View()
+		case kindNewList:
+			prefix := "  "
+			if m.cursor == i {
+				prefix = "> "
+			}
+			s += "\n" + prefix + "Add a new list: " + m.newListTextInput.View()
+		}
 	}
 
+	s += footer
+
+	return s
+}
+
+func (m *model) SaveItems() {
 	data := jsonData{
 		Keys:  m.keys,
 		Lists: m.lists,
 	}
-	asStr, err := json.Marshal(data)
-	if err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
-	}
-
-	if err := os.WriteFile(filepath.Join(dataDir, fileName), asStr, 0o644); err != nil {
+	if err := m.store.Save(data); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 	}
 }
@@ -411,6 +1183,25 @@ func (m *model) getItemCursor() int {
 	return m.cursor - m.listStartOffset
 }
 
+// syncViewport scrolls the items viewport just enough to keep the selected
+// item's row visible, without disturbing the offset otherwise.
+func (m *model) syncViewport() {
+	itemCursor := m.getItemCursor()
+	numItems := 0
+	if len(m.keys) > 0 {
+		numItems = len(m.lists[m.keys[m.selectedList]])
+	}
+	if itemCursor < 0 || itemCursor >= numItems {
+		return
+	}
+
+	if itemCursor < m.viewport.YOffset {
+		m.viewport.SetYOffset(itemCursor)
+	} else if itemCursor >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(itemCursor - m.viewport.Height + 1)
+	}
+}
+
 func (m *model) blurTextInput() {
 	m.textInput.Blur()
 	m.textInput.TextStyle = noStyle
@@ -435,43 +1226,54 @@ func (m *model) focusListTextInput() tea.Cmd {
 	return m.newListTextInput.Focus()
 }
 
-func main() {
-	p := tea.NewProgram(initialModel())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
+// newConfiguredStore picks a Store implementation based on the --store
+// flag (falling back to the TUIDO_STORE env var), so users can choose
+// durability/sync semantics without touching the model.
+func newConfiguredStore() (Store, error) {
+	storeKind := os.Getenv("TUIDO_STORE")
+	storeFlag := flag.String("store", "", "storage backend: json (default), sqlite, or http")
+	flag.Parse()
+	if *storeFlag != "" {
+		storeKind = *storeFlag
 	}
-}
 
-func loadItems() (map[string][]listItem, []string) {
-	data := &jsonData{}
+	switch storeKind {
+	case "", "json":
+		return newJSONFileStore()
 
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		log.Fatal(err)
-	}
+	case "sqlite":
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dbDir := filepath.Join(homeDir, dirName)
+		if err := os.MkdirAll(dbDir, 0o755); err != nil {
+			return nil, err
+		}
+		return newSQLiteStore(filepath.Join(dbDir, "tui-do.db"))
 
-	filePath := filepath.Join(homeDir, dirName, fileName)
-	// Read the whole file at once; the file contains a single JSON array
-	contents, err := os.ReadFile(filePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// No file yet: start with an empty list
-			return make(map[string][]listItem), []string{}
+	case "http":
+		url := os.Getenv("TUIDO_STORE_URL")
+		if url == "" {
+			return nil, fmt.Errorf("tui-do: TUIDO_STORE_URL must be set to use --store=http")
 		}
-		log.Fatal(err)
-	}
+		return newHTTPStore(url), nil
 
-	// Allow empty files to be treated as empty lists
-	if len(contents) == 0 {
-		return make(map[string][]listItem), []string{}
+	default:
+		return nil, fmt.Errorf("tui-do: unknown store %q", storeKind)
 	}
+}
 
-	if err := json.Unmarshal(contents, &data); err != nil {
-		log.Fatal(err)
+func main() {
+	store, err := newConfiguredStore()
+	if err != nil {
+		fmt.Printf("Alas, there's been an error: %v", err)
+		os.Exit(1)
 	}
-	lists := data.Lists
-	keys := data.Keys
 
-	return lists, keys
+	p := tea.NewProgram(initialModel(store), tea.WithMouseCellMotion())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Alas, there's been an error: %v", err)
+		os.Exit(1)
+	}
 }
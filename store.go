@@ -0,0 +1,19 @@
+package main
+
+// Event is delivered on a Store's Watch channel when the underlying data
+// has changed outside of this process. It carries no payload; receivers
+// are expected to call Load again to pick up the new state.
+type Event struct{}
+
+// Store abstracts how todo data is persisted and kept in sync, so the
+// model can swap between a local JSON file, a SQLite database, or a
+// remote HTTP API without knowing which one it's talking to.
+type Store interface {
+	Load() (jsonData, error)
+	Save(jsonData) error
+	// Watch starts delivering an Event on events whenever the store's data
+	// changes externally. It returns immediately; the watch itself runs in
+	// the background for the lifetime of the process. Implementations that
+	// can't observe external changes may return nil without sending events.
+	Watch(events chan<- Event) error
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// httpStore delegates persistence to a remote JSON API, for users who want
+// their todos synced through a server rather than a local file or database.
+type httpStore struct {
+	baseURL string
+	client  *http.Client
+
+	mu       sync.Mutex
+	lastHash string
+}
+
+// newHTTPStore builds an httpStore talking to baseURL, expecting
+// GET/POST /todos to exchange a jsonData document.
+func newHTTPStore(baseURL string) *httpStore {
+	return &httpStore{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// hashJSONData fingerprints a jsonData document so Watch can tell whether
+// the remote has actually changed since it was last observed.
+func hashJSONData(data jsonData) (string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (s *httpStore) Load() (jsonData, error) {
+	resp, err := s.client.Get(s.baseURL + "/todos")
+	if err != nil {
+		return jsonData{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return jsonData{}, fmt.Errorf("tui-do: remote store returned %s", resp.Status)
+	}
+
+	var data jsonData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return jsonData{}, err
+	}
+
+	if h, err := hashJSONData(data); err == nil {
+		s.mu.Lock()
+		s.lastHash = h
+		s.mu.Unlock()
+	}
+
+	return data, nil
+}
+
+func (s *httpStore) Save(data jsonData) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.baseURL+"/todos", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tui-do: remote store returned %s", resp.Status)
+	}
+
+	if h, err := hashJSONData(data); err == nil {
+		s.mu.Lock()
+		s.lastHash = h
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Watch polls the remote server on an interval since plain HTTP has no
+// push mechanism. It only emits an Event when the fetched document's hash
+// differs from the last one observed (by either Load or Save), so a quiet
+// server doesn't make Update reload and clobber in-memory edits that
+// haven't been saved yet - SaveItems only runs on quit.
+func (s *httpStore) Watch(events chan<- Event) error {
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			resp, err := s.client.Get(s.baseURL + "/todos")
+			if err != nil {
+				continue
+			}
+			var data jsonData
+			err = json.NewDecoder(resp.Body).Decode(&data)
+			resp.Body.Close()
+			if err != nil {
+				continue
+			}
+
+			h, err := hashJSONData(data)
+			if err != nil {
+				continue
+			}
+
+			s.mu.Lock()
+			changed := h != s.lastHash
+			s.lastHash = h
+			s.mu.Unlock()
+
+			if changed {
+				select {
+				case events <- Event{}:
+				default:
+				}
+			}
+		}
+	}()
+	return nil
+}
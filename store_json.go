@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// jsonFileStore is the original storage backend: a single JSON file under
+// ~/.tui-do, with fsnotify used to detect edits from other processes.
+type jsonFileStore struct {
+	path string
+}
+
+// newJSONFileStore builds a jsonFileStore rooted at ~/.tui-do/.tui-do.json.
+func newJSONFileStore() (*jsonFileStore, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &jsonFileStore{path: filepath.Join(homeDir, dirName, fileName)}, nil
+}
+
+func (s *jsonFileStore) Load() (jsonData, error) {
+	data := jsonData{}
+
+	contents, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return jsonData{Lists: make(map[string][]listItem), Keys: []string{}}, nil
+		}
+		return jsonData{}, err
+	}
+
+	// Allow empty files to be treated as empty lists
+	if len(contents) == 0 {
+		return jsonData{Lists: make(map[string][]listItem), Keys: []string{}}, nil
+	}
+
+	if err := json.Unmarshal(contents, &data); err != nil {
+		return jsonData{}, err
+	}
+
+	return data, nil
+}
+
+func (s *jsonFileStore) Save(data jsonData) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	asStr, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, asStr, 0o644)
+}
+
+// Watch fires events whenever the data file is written or (re)created,
+// which lets two running tui-do instances (or a manual edit) stay in sync.
+func (s *jsonFileStore) Watch(events chan<- Event) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Name != s.path {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				select {
+				case events <- Event{}:
+				default:
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
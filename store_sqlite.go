@@ -0,0 +1,162 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteStore keeps one row per list (for ordering) and one row per item
+// instead of a single JSON blob. Each Save still rewrites every row, inside
+// one transaction.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// newSQLiteStore opens (creating if necessary) a SQLite database at path
+// and ensures its schema exists.
+func newSQLiteStore(path string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS lists (
+		name     TEXT PRIMARY KEY,
+		position INTEGER NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS items (
+		list_name TEXT NOT NULL REFERENCES lists(name),
+		item      TEXT NOT NULL,
+		completed BOOLEAN NOT NULL,
+		due_date  TEXT,
+		priority  INTEGER NOT NULL DEFAULT 0,
+		tags      TEXT,
+		position  INTEGER NOT NULL
+	);`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Load() (jsonData, error) {
+	data := jsonData{Lists: make(map[string][]listItem)}
+
+	listRows, err := s.db.Query("SELECT name FROM lists ORDER BY position")
+	if err != nil {
+		return jsonData{}, err
+	}
+	defer listRows.Close()
+	for listRows.Next() {
+		var name string
+		if err := listRows.Scan(&name); err != nil {
+			return jsonData{}, err
+		}
+		data.Keys = append(data.Keys, name)
+		data.Lists[name] = []listItem{}
+	}
+	if err := listRows.Err(); err != nil {
+		return jsonData{}, err
+	}
+
+	itemRows, err := s.db.Query(
+		"SELECT list_name, item, completed, due_date, priority, tags FROM items ORDER BY list_name, position",
+	)
+	if err != nil {
+		return jsonData{}, err
+	}
+	defer itemRows.Close()
+	for itemRows.Next() {
+		var listName, item string
+		var completed bool
+		var priority int
+		var dueDate, tags sql.NullString
+		if err := itemRows.Scan(&listName, &item, &completed, &dueDate, &priority, &tags); err != nil {
+			return jsonData{}, err
+		}
+
+		li := listItem{Item: item, Completed: completed, Priority: priority}
+		if dueDate.Valid && dueDate.String != "" {
+			if parsed, err := time.Parse(dueDateLayout, dueDate.String); err == nil {
+				li.DueDate = &parsed
+			}
+		}
+		if tags.Valid && tags.String != "" {
+			var tagList []string
+			if err := json.Unmarshal([]byte(tags.String), &tagList); err == nil {
+				li.Tags = tagList
+			}
+		}
+
+		data.Lists[listName] = append(data.Lists[listName], li)
+	}
+	if err := itemRows.Err(); err != nil {
+		return jsonData{}, err
+	}
+
+	return data, nil
+}
+
+// Save replaces the whole dataset in a single transaction; position
+// columns are re-derived from slice order so manual reordering outside
+// the app can't desync them.
+func (s *sqliteStore) Save(data jsonData) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM items"); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM lists"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i, key := range data.Keys {
+		if _, err := tx.Exec("INSERT INTO lists (name, position) VALUES (?, ?)", key, i); err != nil {
+			tx.Rollback()
+			return err
+		}
+		for j, item := range data.Lists[key] {
+			var dueDate sql.NullString
+			if item.DueDate != nil {
+				dueDate = sql.NullString{String: item.DueDate.Format(dueDateLayout), Valid: true}
+			}
+
+			var tags sql.NullString
+			if len(item.Tags) > 0 {
+				b, err := json.Marshal(item.Tags)
+				if err != nil {
+					tx.Rollback()
+					return err
+				}
+				tags = sql.NullString{String: string(b), Valid: true}
+			}
+
+			if _, err := tx.Exec(
+				"INSERT INTO items (list_name, item, completed, due_date, priority, tags, position) VALUES (?, ?, ?, ?, ?, ?, ?)",
+				key, item.Item, item.Completed, dueDate, item.Priority, tags, j,
+			); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Watch is a no-op: SQLite has no built-in change-notification API, so
+// this backend doesn't support live multi-instance sync.
+func (s *sqliteStore) Watch(events chan<- Event) error {
+	return nil
+}